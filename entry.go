@@ -0,0 +1,9 @@
+package logrus
+
+// WithPrefix tags the Entry with a prefix, stored under FieldKeyPrefix.
+// Unlike an ordinary field, TextFormatter renders the prefix immediately
+// before the message instead of sorting it in with the rest of entry.Data,
+// which makes it useful for per-subsystem or per-component log tagging.
+func (entry *Entry) WithPrefix(prefix string) *Entry {
+	return entry.WithField(FieldKeyPrefix, prefix)
+}
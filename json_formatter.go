@@ -10,9 +10,10 @@ type fieldKey string
 type FieldMap map[fieldKey]string
 
 const (
-	FieldKeyMsg   = "msg"
-	FieldKeyLevel = "level"
-	FieldKeyTime  = "time"
+	FieldKeyMsg    = "msg"
+	FieldKeyLevel  = "level"
+	FieldKeyTime   = "time"
+	FieldKeyPrefix = "prefix"
 )
 
 func (f FieldMap) resolve(key fieldKey) string {
@@ -40,6 +41,19 @@ type JSONFormatter struct {
 	//    },
 	// }
 	FieldMap FieldMap
+
+	// PrettyPrint indents the marshaled JSON with json.MarshalIndent instead
+	// of the default compact encoding.
+	PrettyPrint bool
+
+	// Indent sets the indentation string used when PrettyPrint is enabled.
+	// Defaults to two spaces.
+	Indent string
+
+	// DataKey, if set, nests all of entry.Data under this key instead of
+	// flattening it into the top-level object. This avoids clashes between
+	// user field names and the well-known time/level/msg keys entirely.
+	DataKey string
 }
 
 // The internal representation
@@ -48,25 +62,43 @@ type jsonFormatter struct {
 	keyTime         string
 	keyLevel        string
 	keyMsg          string
+	dataKey         string
+	marshal         func(interface{}) ([]byte, error)
 }
 
 func (factory *JSONFormatter) Build(out io.Writer, minimumLevel Level) (Formatter, error) {
-	fmt := jsonFormatter{
+	jf := jsonFormatter{
 		timestampFormat: factory.TimestampFormat,
 		keyTime:         factory.FieldMap.resolve(FieldKeyTime),
 		keyLevel:        factory.FieldMap.resolve(FieldKeyLevel),
 		keyMsg:          factory.FieldMap.resolve(FieldKeyMsg),
+		dataKey:         factory.DataKey,
+		marshal:         json.Marshal,
 	}
 	if factory.DisableTimestamp {
-		fmt.keyTime = ""
+		jf.keyTime = ""
 	} else {
-		if fmt.timestampFormat == "" {
-			fmt.timestampFormat = DefaultTimestampFormat
+		if jf.timestampFormat == "" {
+			jf.timestampFormat = DefaultTimestampFormat
 		}
 		// TODO more TimestampFormat validation
 	}
 
-	return &fmt, nil
+	if factory.PrettyPrint {
+		indent := factory.Indent
+		if indent == "" {
+			indent = "  "
+		}
+		jf.marshal = func(v interface{}) ([]byte, error) {
+			return json.MarshalIndent(v, "", indent)
+		}
+	}
+
+	if jf.dataKey != "" && (jf.dataKey == jf.keyTime || jf.dataKey == jf.keyLevel || jf.dataKey == jf.keyMsg) {
+		return nil, fmt.Errorf("logrus: DataKey %q clashes with a well-known field key", jf.dataKey)
+	}
+
+	return &jf, nil
 }
 
 func (f *jsonFormatter) Format(entry *Entry) ([]byte, error) {
@@ -81,15 +113,23 @@ func (f *jsonFormatter) Format(entry *Entry) ([]byte, error) {
 			data[k] = v
 		}
 	}
-	prefixFieldClashes(data)
+	var fields Fields
+	if f.dataKey != "" {
+		// Nesting under dataKey already keeps user fields out of the way of
+		// the well-known keys below, so there's nothing left to clash.
+		fields = Fields{f.dataKey: data}
+	} else {
+		prefixFieldClashes(data)
+		fields = data
+	}
 
 	if f.keyTime != "" {
-		data[f.keyTime] = entry.Time.Format(f.timestampFormat)
+		fields[f.keyTime] = entry.Time.Format(f.timestampFormat)
 	}
-	data[f.keyMsg] = entry.Message
-	data[f.keyLevel] = entry.Level.String()
+	fields[f.keyMsg] = entry.Message
+	fields[f.keyLevel] = entry.Level.String()
 
-	serialized, err := json.Marshal(data)
+	serialized, err := f.marshal(fields)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to marshal fields to JSON, %v", err)
 	}
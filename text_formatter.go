@@ -8,25 +8,133 @@ import (
 	"sort"
 	"strings"
 	"time"
-)
 
-const (
-	nocolor = 0
-	red     = 31
-	green   = 32
-	yellow  = 33
-	blue    = 34
-	gray    = 37
+	"github.com/mgutz/ansi"
 )
 
 var (
 	baseTimestamp time.Time
+
+	// defaultColorScheme reproduces the colors historically hard-coded in
+	// printColored, so formatters that don't set ColorScheme see no change.
+	// KeyStyle and TimestampStyle are left empty: field keys historically
+	// took the level's own color, and the timestamp wasn't colored at all,
+	// so those are the defaults here too.
+	defaultColorScheme = &ColorScheme{
+		InfoLevelStyle:  "blue",
+		WarnLevelStyle:  "yellow",
+		ErrorLevelStyle: "red",
+		FatalLevelStyle: "red",
+		PanicLevelStyle: "red",
+		DebugLevelStyle: "white",
+		PrefixStyle:     "cyan",
+	}
+
+	// NoColors is a sentinel ColorScheme: Build() recognizes it by identity
+	// and compiles every style to a no-op instead of consulting defaultColorScheme.
+	NoColors = &ColorScheme{}
+
+	defaultCompiledColorScheme = compileColorScheme(defaultColorScheme)
+	noColorsCompiledScheme     = &compiledColorScheme{
+		InfoLevelColor:  noopColor,
+		WarnLevelColor:  noopColor,
+		ErrorLevelColor: noopColor,
+		FatalLevelColor: noopColor,
+		PanicLevelColor: noopColor,
+		DebugLevelColor: noopColor,
+		PrefixColor:     noopColor,
+		TimestampColor:  noopColor,
+		KeyColor:        noopColor,
+	}
 )
 
+func noopColor(s string) string { return s }
+
 func init() {
 	baseTimestamp = time.Now()
 }
 
+// ColorScheme lets callers override the ANSI styling TextFormatter applies
+// to each element of a colored log line. Each field is a style spec as
+// understood by github.com/mgutz/ansi, e.g. "green", "red+b", "black+h".
+// Fields left empty fall back to the corresponding defaultColorScheme entry,
+// except KeyStyle (left empty, field keys are colored to match the level)
+// and TimestampStyle (left empty, the timestamp isn't colored at all).
+type ColorScheme struct {
+	InfoLevelStyle  string
+	WarnLevelStyle  string
+	ErrorLevelStyle string
+	FatalLevelStyle string
+	PanicLevelStyle string
+	DebugLevelStyle string
+	PrefixStyle     string
+	TimestampStyle  string
+	KeyStyle        string
+}
+
+// compiledColorScheme holds the color functions produced from a ColorScheme
+// at Build() time, so Format() never has to parse a style spec.
+type compiledColorScheme struct {
+	InfoLevelColor  func(string) string
+	WarnLevelColor  func(string) string
+	ErrorLevelColor func(string) string
+	FatalLevelColor func(string) string
+	PanicLevelColor func(string) string
+	DebugLevelColor func(string) string
+	PrefixColor     func(string) string
+
+	// TimestampColor is nil when TimestampStyle wasn't set, meaning "leave
+	// the timestamp uncolored", matching historical printColored behavior.
+	TimestampColor func(string) string
+
+	// KeyColor is nil when KeyStyle wasn't set, meaning "match the level's
+	// color"; callers must fall back to levelColor(entry.Level) in that case.
+	KeyColor func(string) string
+}
+
+func styleOrDefault(style, fallback string) string {
+	if style == "" {
+		return fallback
+	}
+	return style
+}
+
+func compileColorScheme(s *ColorScheme) *compiledColorScheme {
+	c := &compiledColorScheme{
+		InfoLevelColor:  ansi.ColorFunc(styleOrDefault(s.InfoLevelStyle, defaultColorScheme.InfoLevelStyle)),
+		WarnLevelColor:  ansi.ColorFunc(styleOrDefault(s.WarnLevelStyle, defaultColorScheme.WarnLevelStyle)),
+		ErrorLevelColor: ansi.ColorFunc(styleOrDefault(s.ErrorLevelStyle, defaultColorScheme.ErrorLevelStyle)),
+		FatalLevelColor: ansi.ColorFunc(styleOrDefault(s.FatalLevelStyle, defaultColorScheme.FatalLevelStyle)),
+		PanicLevelColor: ansi.ColorFunc(styleOrDefault(s.PanicLevelStyle, defaultColorScheme.PanicLevelStyle)),
+		DebugLevelColor: ansi.ColorFunc(styleOrDefault(s.DebugLevelStyle, defaultColorScheme.DebugLevelStyle)),
+		PrefixColor:     ansi.ColorFunc(styleOrDefault(s.PrefixStyle, defaultColorScheme.PrefixStyle)),
+	}
+	if s.TimestampStyle != "" {
+		c.TimestampColor = ansi.ColorFunc(s.TimestampStyle)
+	}
+	if s.KeyStyle != "" {
+		c.KeyColor = ansi.ColorFunc(s.KeyStyle)
+	}
+	return c
+}
+
+func (s *compiledColorScheme) levelColor(level Level) func(string) string {
+	switch level {
+	case DebugLevel:
+		return s.DebugLevelColor
+	case WarnLevel:
+		return s.WarnLevelColor
+	case ErrorLevel:
+		return s.ErrorLevelColor
+	case FatalLevel:
+		return s.FatalLevelColor
+	case PanicLevel:
+		return s.PanicLevelColor
+	default:
+		return s.InfoLevelColor
+	}
+}
+
 type TextFormatter struct {
 	// Set to true to bypass checking for a TTY before outputting colors.
 	ForceColors bool
@@ -56,12 +164,29 @@ type TextFormatter struct {
 	// QuoteCharacter can be set to the override the default quoting character "
 	// with something else. For example: ', or `.
 	QuoteCharacter string
+
+	// ColorScheme lets callers override the ANSI styling used for each
+	// element of a colored log line. A nil value (the default) reproduces
+	// the historical fixed colors. Set to NoColors to disable styling
+	// while keeping the colored layout.
+	ColorScheme *ColorScheme
+
+	// QuickTimestamp renders the compact LEVEL[0000] elapsed-seconds layout
+	// (normally only seen on a color TTY) in the non-colored branch too, so
+	// output redirected to a file or a non-TTY reader stays aligned and
+	// scannable. Mutually exclusive with FullTimestamp and DisableTimestamp.
+	QuickTimestamp bool
+
+	// PadMsgWidth sets the width of the message column in the QuickTimestamp
+	// (and colored) layout. Defaults to 44.
+	PadMsgWidth int
 }
 
 // Our internal representation
 type textFormatter struct {
 	settings  TextFormatter
 	isColored bool
+	colors    *compiledColorScheme
 }
 
 func (factory *TextFormatter) Build(out io.Writer, minimumLevel Level) (Formatter, error) {
@@ -74,18 +199,59 @@ func (factory *TextFormatter) Build(out io.Writer, minimumLevel Level) (Formatte
 		factory.QuoteCharacter = `"`
 	}
 
+	if factory.QuickTimestamp {
+		if factory.FullTimestamp {
+			return nil, fmt.Errorf("logrus: QuickTimestamp cannot be combined with FullTimestamp")
+		}
+		if factory.DisableTimestamp {
+			return nil, fmt.Errorf("logrus: QuickTimestamp cannot be combined with DisableTimestamp")
+		}
+	}
+
+	if factory.PadMsgWidth < 0 {
+		return nil, fmt.Errorf("logrus: PadMsgWidth must not be negative")
+	}
+	if factory.PadMsgWidth == 0 {
+		factory.PadMsgWidth = 44
+	}
+
 	isColorTerminal := IsTerminal(out) && (runtime.GOOS != "windows")
+
+	colors := defaultCompiledColorScheme
+	switch {
+	case factory.DisableColors:
+		colors = noColorsCompiledScheme
+	case factory.ColorScheme == NoColors:
+		colors = noColorsCompiledScheme
+	case factory.ColorScheme != nil:
+		colors = compileColorScheme(factory.ColorScheme)
+	}
+
 	return &textFormatter{
 		settings: *factory,
 		isColored: (factory.ForceColors || isColorTerminal) &&
 			!factory.DisableColors,
+		colors: colors,
 	}, nil
 }
 
 func (f *textFormatter) Format(entry *Entry) ([]byte, error) {
 	prefixFieldClashes(entry.Data)
+
+	var prefix string
+	if p, ok := entry.Data[FieldKeyPrefix]; ok {
+		if ps, ok := p.(string); ok {
+			prefix = ps
+		} else {
+			prefix = fmt.Sprint(p)
+		}
+	}
+
 	keys := make([]string, 0, len(entry.Data))
 	for k := range entry.Data {
+		if k == FieldKeyPrefix {
+			continue
+		}
 		keys = append(keys, k)
 	}
 
@@ -99,12 +265,17 @@ func (f *textFormatter) Format(entry *Entry) ([]byte, error) {
 	}
 
 	if f.isColored {
-		f.printColored(b, entry, keys)
+		f.printColored(b, entry, keys, prefix)
+	} else if f.settings.QuickTimestamp {
+		f.printQuick(b, entry, keys, prefix)
 	} else {
 		if !f.settings.DisableTimestamp {
 			f.appendKeyValue(b, "time", entry.Time.Format(f.settings.TimestampFormat))
 		}
 		f.appendKeyValue(b, "level", entry.Level.String())
+		if prefix != "" {
+			f.appendKeyValue(b, FieldKeyPrefix, prefix)
+		}
 		if entry.Message != "" {
 			f.appendKeyValue(b, "msg", entry.Message)
 		}
@@ -117,31 +288,53 @@ func (f *textFormatter) Format(entry *Entry) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
-func (f *textFormatter) printColored(b *bytes.Buffer, entry *Entry, keys []string) {
-	var levelColor int
-	switch entry.Level {
-	case DebugLevel:
-		levelColor = gray
-	case WarnLevel:
-		levelColor = yellow
-	case ErrorLevel, FatalLevel, PanicLevel:
-		levelColor = red
-	default:
-		levelColor = blue
+func (f *textFormatter) printColored(b *bytes.Buffer, entry *Entry, keys []string, prefix string) {
+	levelColor := f.colors.levelColor(entry.Level)
+	levelText := levelColor(strings.ToUpper(entry.Level.String())[0:4])
+
+	timestampColor := f.colors.TimestampColor
+	if timestampColor == nil {
+		timestampColor = noopColor
 	}
 
-	levelText := strings.ToUpper(entry.Level.String())[0:4]
+	if prefix != "" {
+		prefix = f.colors.PrefixColor(fmt.Sprintf("[%s] ", prefix))
+	}
 
 	if f.settings.DisableTimestamp {
-		fmt.Fprintf(b, "\x1b[%dm%s\x1b[0m %-44s ", levelColor, levelText, entry.Message)
+		fmt.Fprintf(b, "%s %s%-*s ", levelText, prefix, f.settings.PadMsgWidth, entry.Message)
 	} else if !f.settings.FullTimestamp {
-		fmt.Fprintf(b, "\x1b[%dm%s\x1b[0m[%04d] %-44s ", levelColor, levelText, int(entry.Time.Sub(baseTimestamp)/time.Second), entry.Message)
+		timestamp := timestampColor(fmt.Sprintf("[%04d]", int(entry.Time.Sub(baseTimestamp)/time.Second)))
+		fmt.Fprintf(b, "%s%s %s%-*s ", levelText, timestamp, prefix, f.settings.PadMsgWidth, entry.Message)
 	} else {
-		fmt.Fprintf(b, "\x1b[%dm%s\x1b[0m[%s] %-44s ", levelColor, levelText, entry.Time.Format(f.settings.TimestampFormat), entry.Message)
+		timestamp := timestampColor(fmt.Sprintf("[%s]", entry.Time.Format(f.settings.TimestampFormat)))
+		fmt.Fprintf(b, "%s%s %s%-*s ", levelText, timestamp, prefix, f.settings.PadMsgWidth, entry.Message)
+	}
+	keyColor := f.colors.KeyColor
+	if keyColor == nil {
+		keyColor = levelColor
+	}
+	for _, k := range keys {
+		v := entry.Data[k]
+		fmt.Fprintf(b, " %s=", keyColor(k))
+		f.appendValue(b, v)
+	}
+}
+
+// printQuick renders the same compact LEVEL[0000] message<pad> key=value
+// layout as printColored, minus the ANSI escapes, for QuickTimestamp in
+// non-TTY output.
+func (f *textFormatter) printQuick(b *bytes.Buffer, entry *Entry, keys []string, prefix string) {
+	levelText := strings.ToUpper(entry.Level.String())[0:4]
+
+	if prefix != "" {
+		prefix = fmt.Sprintf("[%s] ", prefix)
 	}
+
+	fmt.Fprintf(b, "%s[%04d] %s%-*s ", levelText, int(entry.Time.Sub(baseTimestamp)/time.Second), prefix, f.settings.PadMsgWidth, entry.Message)
 	for _, k := range keys {
 		v := entry.Data[k]
-		fmt.Fprintf(b, " \x1b[%dm%s\x1b[0m=", levelColor, k)
+		fmt.Fprintf(b, " %s=", k)
 		f.appendValue(b, v)
 	}
 }